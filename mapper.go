@@ -4,7 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 )
 
 // Mapper holds config.
@@ -18,15 +23,139 @@ type Mapper struct {
 	FuzzyMatchFieldNames     bool
 	CustomMappers            []CustomFieldMapper
 	IgnoreDestFields         []string
+	// SourceNameMapper, if set, reformats a source field name (after SourceTag
+	// resolution) before it's compared against the destination field name.
+	SourceNameMapper NameMapper
+	// DestNameMapper, if set, reformats a destination field name (after DestTag
+	// resolution) before it's compared against the source field name.
+	DestNameMapper NameMapper
+	// Tag is the struct tag name parsed for per-field options (skip, rename,
+	// required, omitempty, convert). Defaults to "mapper" when empty.
+	Tag string
+	// Converters is a registry of named CustomFieldMapper funcs, looked up by
+	// a field's `convert=Name` tag option.
+	Converters map[string]CustomFieldMapper
+	// TimeLayouts are tried in order when parsing a string into a time.Time.
+	// Defaults to []string{time.RFC3339} when empty. The first layout is also
+	// used to format a time.Time back into a string.
+	TimeLayouts []string
+	// StringCoercions enables the built-in string<->time.Time, string<->time.Duration
+	// and string<->bool/int/float "wire format" conversions, attempted just before
+	// mapValues would otherwise give up on an unsupported type pair. Zero value
+	// disables all of them; see DefaultMapper, which enables AllStringCoercions.
+	StringCoercions StringCoercion
 }
 
+// StringCoercion is a bitmask of built-in string<->scalar conversions that
+// Mapper.StringCoercions can enable.
+type StringCoercion uint8
+
+// Individually toggleable string coercions. Combine with | to enable more than one.
+const (
+	StringToTime StringCoercion = 1 << iota
+	TimeToString
+	StringToDuration
+	DurationToString
+	StringToNumber
+	NumberToString
+
+	// AllStringCoercions enables every built-in string coercion.
+	AllStringCoercions = StringToTime | TimeToString | StringToDuration | DurationToString | StringToNumber | NumberToString
+)
+
 // CustomFieldMapper is the function signature for custom mappers.
 type CustomFieldMapper func(sourceVal reflect.Value, sourceType reflect.Type, destVal reflect.Value, destType reflect.Type) (handled bool)
 
+// NameMapper reformats a field name into a different naming convention, eg.
+// turning "UserID" into "user_id". Used by Mapper.SourceNameMapper and
+// Mapper.DestNameMapper so one Mapper can bridge two DTO layers that each
+// favor a different convention, instead of listing every pair in
+// FieldNameMaps.
+type NameMapper func(string) string
+
+// SnakeCase is a NameMapper that turns "UserID" into "user_id".
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitNameWords(name), "_"))
+}
+
+// KebabCase is a NameMapper that turns "UserID" into "user-id".
+func KebabCase(name string) string {
+	return strings.ToLower(strings.Join(splitNameWords(name), "-"))
+}
+
+// AllCapsUnderscore is a NameMapper that turns "UserID" into "USER_ID".
+func AllCapsUnderscore(name string) string {
+	return strings.ToUpper(strings.Join(splitNameWords(name), "_"))
+}
+
+// PascalCase is a NameMapper that turns "user_id" into "UserId".
+func PascalCase(name string) string {
+	var b strings.Builder
+	for _, word := range splitNameWords(name) {
+		b.WriteString(titleCaseWord(word))
+	}
+	return b.String()
+}
+
+// LowerCamelCase is a NameMapper that turns "user_id" into "userId".
+func LowerCamelCase(name string) string {
+	var b strings.Builder
+	for i, word := range splitNameWords(name) {
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+			continue
+		}
+		b.WriteString(titleCaseWord(word))
+	}
+	return b.String()
+}
+
+// splitNameWords splits a field name into its component words, regardless of
+// whether it arrived snake_case, kebab-case, PascalCase, camelCase or
+// SHOUTING_SNAKE_CASE.
+func splitNameWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+	for i, r := range runes {
+		if r == '_' || r == '-' || r == ' ' {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+// titleCaseWord lowercases word and then uppercases its first rune.
+func titleCaseWord(word string) string {
+	if word == "" {
+		return word
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
 // DefaultMapper hold default configuration for basic mapping
 var DefaultMapper = Mapper{
 	PanicOnIncompatibleTypes: true,
 	PanicOnMissingField:      true,
+	StringCoercions:          AllStringCoercions,
 }
 
 // Result is the returned data from the Map() function.
@@ -87,10 +216,16 @@ func (r *Result) Error() error {
 
 // mapValues maps field values from a source struct to a destination struct.
 func (m *Mapper) mapValues(result *Result, sourceVal, destVal reflect.Value) {
+	// unwrap interface values (eg. pulled out of a map[string]interface{}) to their
+	// concrete dynamic value, so the usual type-based branches below still apply.
+	if sourceVal.Kind() == reflect.Interface && !sourceVal.IsNil() {
+		sourceVal = sourceVal.Elem()
+	}
+
 	destType := destVal.Type()
 
-	if destType.Kind() == reflect.Struct {
-		// dereference source pointer structs
+	if destType.Kind() == reflect.Struct || destType.Kind() == reflect.Map {
+		// dereference source pointer structs (or pointers to maps, when flattening into a map)
 		if sourceVal.Type().Kind() == reflect.Ptr {
 			// if the source is nil, create a new instance of it so we can copy the blank fields over.
 			// I believe if you skip this and return immediately it would be fine,
@@ -139,11 +274,34 @@ func (m *Mapper) mapValues(result *Result, sourceVal, destVal reflect.Value) {
 		}
 	}
 
-	// if destination type is a struct, iterate dest struct's fields and map the field
-	if destType.Kind() == reflect.Struct {
-		for i := 0; i < destVal.NumField(); i++ {
-			result.scope = append(result.scope, destType.Field(i).Name)
-			m.mapField(result, sourceVal, destVal, i)
+	// if destination type is a map, either copy matching entries from a source map, or
+	// flatten a source struct's fields into map entries.
+	if destType.Kind() == reflect.Map {
+		m.mapToMap(result, sourceVal, destVal)
+		return
+	}
+
+	// if destination type is a struct, iterate dest struct's fields and map the field.
+	// A dest struct with a non-struct, non-map source (eg. a string coerced into a
+	// time.Time below) isn't handled here; it falls through to the conversions further down.
+	if destType.Kind() == reflect.Struct && (sourceType.Kind() == reflect.Map || sourceType.Kind() == reflect.Struct) {
+		// populate the struct's fields by looking their formatted names up in a source map.
+		if sourceType.Kind() == reflect.Map {
+			m.mapFromMapToStruct(result, sourceVal, destVal)
+			return
+		}
+
+		// CustomMappers run on every value pair mapValues sees, including nested struct
+		// fields, so a precompiled plan (which skips most of those calls) can only be
+		// trusted when there are none registered.
+		if len(m.CustomMappers) == 0 {
+			m.execPlan(result, m.planFor(sourceType, destType), sourceVal, destVal)
+			return
+		}
+
+		for _, destPf := range promotedFieldsFor(destType) {
+			result.scope = append(result.scope, destPf.field.Name)
+			m.mapField(result, sourceVal, destVal, destPf)
 
 			// NOTE: I think these scope checks are no longer necessary. Since implementing the result object, Map should be goroutine-safe as it has no internal state.
 			if len(result.scope) == 0 {
@@ -191,12 +349,24 @@ func (m *Mapper) mapValues(result *Result, sourceVal, destVal reflect.Value) {
 		return
 	}
 
+	// if dest is an interface the source value satisfies (eg. a map[string]interface{}
+	// entry), assign directly rather than falling through to the error branch below.
+	if destType.Kind() == reflect.Interface && sourceVal.Type().AssignableTo(destType) {
+		destVal.Set(sourceVal)
+		return
+	}
+
 	// for slices, map the slice.
 	if destType.Kind() == reflect.Slice {
 		m.mapSlice(result, sourceVal, destVal)
 		return
 	}
 
+	// try the built-in string<->time/duration/number coercions before giving up.
+	if m.coerceString(result, sourceVal, destVal) {
+		return
+	}
+
 	errMsg := fmt.Sprintf("Currently not supported (source %s -> dest %s), write a custom mapper for this. see CustomFieldMapper.", sourceVal.Type(), destVal.Type())
 	if m.PanicOnIncompatibleTypes {
 		panic(errMsg)
@@ -204,14 +374,18 @@ func (m *Mapper) mapValues(result *Result, sourceVal, destVal reflect.Value) {
 	result.addError(errMsg)
 }
 
-// mapField maps a specific field on a struct type from source to destination.
-func (m *Mapper) mapField(result *Result, source, destVal reflect.Value, i int) {
-	destType := destVal.Type()
+// mapField maps a specific promoted field on a struct type from source to destination.
+func (m *Mapper) mapField(result *Result, source, destVal reflect.Value, destPf promotedField) {
+	destFieldType := destPf.field
+	destOpts := m.fieldOptionsFor(destPf.owner)[destFieldType.Name]
+	if destOpts.skip || m.destFieldIgnored(destPf) {
+		return
+	}
 
 	// catch any type-conversion panic so we can add some context to it.
 	defer func() {
 		if r := recover(); r != nil {
-			errMsg := fmt.Sprintf("Error mapping field: %s. DestType: %v. SourceType: %v. Error: %v", destType.Field(i).Name, destType, source.Type(), r)
+			errMsg := fmt.Sprintf("Error mapping field: %s. DestType: %v. SourceType: %v. Error: %v", destFieldType.Name, destVal.Type(), source.Type(), r)
 			if m.PanicOnIncompatibleTypes {
 				panic(errMsg)
 			}
@@ -219,37 +393,51 @@ func (m *Mapper) mapField(result *Result, source, destVal reflect.Value, i int)
 		}
 	}()
 
-	destField := destVal.Field(i)
-	sourceField := m.findSourceField(result, source, destType.Field(i))
+	destField := fieldByIndexPathForSet(destVal, destPf.index)
+	sourceField := m.findSourceField(result, source, destFieldType, destOpts)
 	if !sourceField.IsValid() {
 		return
 	}
+
+	if destOpts.required && isZeroValue(sourceField) {
+		result.addError(fmt.Sprintf("Required field has a zero value: %s", result.scopedFieldName()))
+		return
+	}
+	if destOpts.omitempty && isZeroValue(sourceField) && !isZeroValue(destField) {
+		return
+	}
+	if destOpts.convert != "" {
+		if converter, ok := m.Converters[destOpts.convert]; ok {
+			if handled := converter(sourceField, sourceField.Type(), destField, destField.Type()); handled {
+				return
+			}
+		}
+	}
 	m.mapValues(result, sourceField, destField)
 }
 
-// findSourceField finds the matching field on the source object.
-func (m *Mapper) findSourceField(result *Result, source reflect.Value, destFieldType reflect.StructField) reflect.Value {
-	destFieldName := m.formattedDestFieldName(destFieldType)
+// findSourceField finds the matching field on the source object, searching its
+// promoted field set so fields embedded via anonymous structs (at any depth) are
+// considered alongside the struct's own directly-declared fields.
+func (m *Mapper) findSourceField(result *Result, source reflect.Value, destFieldType reflect.StructField, destOpts fieldOptions) reflect.Value {
+	destFieldName := m.formattedDestFieldName(destFieldType, destOpts)
 	if m.includes(m.IgnoreDestFields, destFieldName) {
 		return reflect.Value{}
 	}
-	for i := 0; i < source.NumField(); i++ {
-		sourceFieldName := m.formattedSourceFieldName(source.Type().Field(i))
-		if sourceFieldName == destFieldName {
-			return source.Field(i)
+	for _, pf := range promotedFieldsFor(source.Type()) {
+		opts := m.fieldOptionsFor(pf.owner)[pf.field.Name]
+		if opts.skip {
+			continue
 		}
-	}
-
-	// if we didn't find the field, try looking in anonymous composed structs.
-	for i := 0; i < source.NumField(); i++ {
-		sourceField := source.Type().Field(i)
-		if sourceField.Anonymous && sourceField.Type.Kind() == reflect.Struct {
-			// probe anonymous structs recursively, but discard the result, since not finding the field in _this_ struct is not evidence that it's missing, per se. It'd have to be missing from all composed structs and the parent struct to truly be missing.
-			sourceFieldFound := m.findSourceField(&Result{}, source.Field(i), destFieldType)
-			if sourceFieldFound.IsValid() {
-				return sourceFieldFound
-			}
+		if m.formattedSourceFieldName(pf.field, opts) != destFieldName {
+			continue
+		}
+		if sourceField, ok := fieldByIndexPath(source, pf.index); ok {
+			return sourceField
 		}
+		// the promoted field's name matched, but an anonymous pointer embed along the
+		// way was nil, so there's nothing to read; fall through and report it missing.
+		break
 	}
 
 	result.MissingSourceFields = append(result.MissingSourceFields, result.scopedFieldName())
@@ -274,13 +462,18 @@ func (r *Result) scopedFieldName() string {
 }
 
 // formattedDestFieldName is the name of the field in the destination object, with configuration options taken into account
-func (m *Mapper) formattedDestFieldName(f reflect.StructField) string {
+func (m *Mapper) formattedDestFieldName(f reflect.StructField, opts fieldOptions) string {
 	fieldName := f.Name
-	if len(m.DestTag) > 0 {
+	if opts.rename != "" {
+		fieldName = opts.rename
+	} else if len(m.DestTag) > 0 {
 		if f, ok := f.Tag.Lookup(m.DestTag); ok {
 			fieldName = strings.Split(f, ",")[0]
 		}
 	}
+	if m.DestNameMapper != nil {
+		fieldName = m.DestNameMapper(fieldName)
+	}
 	fieldName = m.fuzzy(fieldName)
 	for k, v := range m.FieldNameMaps {
 		if m.fuzzy(v) == fieldName {
@@ -291,13 +484,18 @@ func (m *Mapper) formattedDestFieldName(f reflect.StructField) string {
 }
 
 // formattedSourceFieldName is the name of the field in the source object, with certain configuration options taken into account
-func (m *Mapper) formattedSourceFieldName(f reflect.StructField) string {
+func (m *Mapper) formattedSourceFieldName(f reflect.StructField, opts fieldOptions) string {
 	fieldName := f.Name
-	if len(m.SourceTag) > 0 {
+	if opts.rename != "" {
+		fieldName = opts.rename
+	} else if len(m.SourceTag) > 0 {
 		if f, ok := f.Tag.Lookup(m.SourceTag); ok {
 			fieldName = strings.Split(f, ",")[0]
 		}
 	}
+	if m.SourceNameMapper != nil {
+		fieldName = m.SourceNameMapper(fieldName)
+	}
 	return m.fuzzy(fieldName)
 }
 
@@ -317,6 +515,687 @@ func valueIsNil(value reflect.Value) bool {
 	return value.Type().Kind() == reflect.Ptr && value.IsNil()
 }
 
+// promotedField is one field reachable on a struct type, either declared directly or
+// promoted up through an anonymous (embedded) field, following the same precedence
+// rules as reflect.VisibleFields: a field at a shallower embedding depth shadows one
+// of the same name at a deeper depth, and two fields tied for shallowest depth are
+// ambiguous and excluded entirely, rather than arbitrarily picking one.
+type promotedField struct {
+	field     reflect.StructField
+	owner     reflect.Type // the struct type that declares field, for fieldOptionsFor lookups
+	index     []int        // index path from the root type down to field, through any embeds
+	ancestors []ancestorField
+}
+
+// ancestorField is one anonymous field on the path from a struct's root type down to
+// a promotedField, used to check IgnoreDestFields against every embed the field was
+// promoted through, not just the field's own name.
+type ancestorField struct {
+	field reflect.StructField
+	owner reflect.Type
+}
+
+// promotedFieldsCache holds the computed promotedField set per struct type, since the
+// BFS over anonymous fields only depends on the type, not on any particular Mapper.
+var promotedFieldsCache sync.Map // reflect.Type -> []promotedField
+
+// promotedFieldsFor returns t's promoted field set, computing and caching it on first use.
+func promotedFieldsFor(t reflect.Type) []promotedField {
+	if cached, ok := promotedFieldsCache.Load(t); ok {
+		return cached.([]promotedField)
+	}
+	fields := computePromotedFields(t)
+	cached, _ := promotedFieldsCache.LoadOrStore(t, fields)
+	return cached.([]promotedField)
+}
+
+// computePromotedFields does a breadth-first walk of t's fields, descending into
+// anonymous struct (or pointer-to-struct) fields, and resolves name collisions by
+// embedding depth: shallowest wins, ties are dropped as ambiguous.
+func computePromotedFields(t reflect.Type) []promotedField {
+	type candidate struct {
+		pf    promotedField
+		depth int
+	}
+	candidatesByName := make(map[string][]candidate)
+	var nameOrder []string
+
+	type queueEntry struct {
+		t         reflect.Type
+		index     []int
+		depth     int
+		ancestors []ancestorField
+	}
+	queue := []queueEntry{{t: t, depth: 0}}
+	for len(queue) > 0 {
+		entry := queue[0]
+		queue = queue[1:]
+		for i := 0; i < entry.t.NumField(); i++ {
+			f := entry.t.Field(i)
+			index := append(append([]int{}, entry.index...), i)
+			if _, seen := candidatesByName[f.Name]; !seen {
+				nameOrder = append(nameOrder, f.Name)
+			}
+			candidatesByName[f.Name] = append(candidatesByName[f.Name], candidate{
+				pf:    promotedField{field: f, owner: entry.t, index: index, ancestors: entry.ancestors},
+				depth: entry.depth,
+			})
+
+			if !f.Anonymous {
+				continue
+			}
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				childAncestors := append(append([]ancestorField{}, entry.ancestors...), ancestorField{field: f, owner: entry.t})
+				queue = append(queue, queueEntry{t: embedded, index: index, depth: entry.depth + 1, ancestors: childAncestors})
+			}
+		}
+	}
+
+	fields := make([]promotedField, 0, len(nameOrder))
+	for _, name := range nameOrder {
+		candidates := candidatesByName[name]
+		shallowest := candidates[0].depth
+		for _, c := range candidates[1:] {
+			if c.depth < shallowest {
+				shallowest = c.depth
+			}
+		}
+		var atShallowest []candidate
+		for _, c := range candidates {
+			if c.depth == shallowest {
+				atShallowest = append(atShallowest, c)
+			}
+		}
+		if len(atShallowest) != 1 {
+			// two or more fields tied for the shallowest depth share this name: ambiguous.
+			continue
+		}
+		fields = append(fields, atShallowest[0].pf)
+	}
+	return fields
+}
+
+// destFieldIgnored reports whether pf was promoted through an anonymous embed whose
+// own formatted dest name is in m.IgnoreDestFields, so that ignoring an embedded
+// struct by name (eg. IgnoreDestFields: []string{"Model"}) ignores its whole promoted
+// subtree, not just the literal "Model" field itself.
+func (m *Mapper) destFieldIgnored(pf promotedField) bool {
+	for _, anc := range pf.ancestors {
+		ancOpts := m.fieldOptionsFor(anc.owner)[anc.field.Name]
+		if m.includes(m.IgnoreDestFields, m.formattedDestFieldName(anc.field, ancOpts)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldByIndexPath reads the field at index within v, dereferencing any anonymous
+// pointer embeds along the way. ok is false if a nil embed made the field unreachable.
+func fieldByIndexPath(v reflect.Value, index []int) (result reflect.Value, ok bool) {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// fieldByIndexPathForSet is fieldByIndexPath for a destination value: nil anonymous
+// pointer embeds along the path are allocated instead of treated as unreachable.
+func fieldByIndexPathForSet(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v
+}
+
+// mapToMap maps a dest map either from a source map, by recursively mapping each
+// matching entry, or from a source struct, by flattening its fields into entries.
+func (m *Mapper) mapToMap(result *Result, sourceVal, destVal reflect.Value) {
+	destType := destVal.Type()
+	sourceType := sourceVal.Type()
+
+	if sourceType.Kind() == reflect.Map {
+		if sourceVal.IsNil() {
+			return
+		}
+		if sourceType.Key() != destType.Key() {
+			errMsg := fmt.Sprintf("Currently not supported (source map key %s -> dest map key %s), write a custom mapper for this. see CustomFieldMapper.", sourceType.Key(), destType.Key())
+			if m.PanicOnIncompatibleTypes {
+				panic(errMsg)
+			}
+			result.addError(errMsg)
+			return
+		}
+		target := reflect.MakeMapWithSize(destType, sourceVal.Len())
+		for _, key := range sourceVal.MapKeys() {
+			val := reflect.New(destType.Elem()).Elem()
+			m.mapValues(result, sourceVal.MapIndex(key), val)
+			target.SetMapIndex(key, val)
+		}
+		destVal.Set(target)
+		return
+	}
+
+	if sourceType.Kind() == reflect.Struct {
+		if destType.Key().Kind() != reflect.String {
+			errMsg := fmt.Sprintf("Currently not supported (source struct -> dest map key %s), write a custom mapper for this. see CustomFieldMapper.", destType.Key())
+			if m.PanicOnIncompatibleTypes {
+				panic(errMsg)
+			}
+			result.addError(errMsg)
+			return
+		}
+		sourceOpts := m.fieldOptionsFor(sourceType)
+		target := reflect.MakeMapWithSize(destType, sourceType.NumField())
+		for i := 0; i < sourceType.NumField(); i++ {
+			sourceFieldType := sourceType.Field(i)
+			opts := sourceOpts[sourceFieldType.Name]
+			if opts.skip {
+				continue
+			}
+			fieldName := m.formattedSourceFieldName(sourceFieldType, opts)
+			val := reflect.New(destType.Elem()).Elem()
+			m.mapValues(result, sourceVal.Field(i), val)
+			target.SetMapIndex(reflect.ValueOf(fieldName).Convert(destType.Key()), val)
+		}
+		destVal.Set(target)
+		return
+	}
+
+	errMsg := fmt.Sprintf("Currently not supported (source %s -> dest %s), write a custom mapper for this. see CustomFieldMapper.", sourceVal.Type(), destVal.Type())
+	if m.PanicOnIncompatibleTypes {
+		panic(errMsg)
+	}
+	result.addError(errMsg)
+}
+
+// mapFromMapToStruct populates a dest struct's fields by looking up their formatted
+// names among a source map[string]T's keys.
+func (m *Mapper) mapFromMapToStruct(result *Result, sourceVal, destVal reflect.Value) {
+	destType := destVal.Type()
+	if sourceVal.Type().Key().Kind() != reflect.String {
+		errMsg := fmt.Sprintf("Currently not supported (source map key %s -> dest struct), write a custom mapper for this. see CustomFieldMapper.", sourceVal.Type().Key())
+		if m.PanicOnIncompatibleTypes {
+			panic(errMsg)
+		}
+		result.addError(errMsg)
+		return
+	}
+
+	for _, destPf := range promotedFieldsFor(destType) {
+		result.scope = append(result.scope, destPf.field.Name)
+
+		destFieldType := destPf.field
+		destOpts := m.fieldOptionsFor(destPf.owner)[destFieldType.Name]
+		if destOpts.skip || m.destFieldIgnored(destPf) {
+			result.scope = result.scope[0 : len(result.scope)-1]
+			continue
+		}
+		destFieldName := m.formattedDestFieldName(destFieldType, destOpts)
+
+		found := false
+		for _, key := range sourceVal.MapKeys() {
+			if m.formattedMapKeyName(key.String()) == destFieldName {
+				m.mapValues(result, sourceVal.MapIndex(key), fieldByIndexPathForSet(destVal, destPf.index))
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.MissingSourceFields = append(result.MissingSourceFields, result.scopedFieldName())
+		}
+
+		result.scope = result.scope[0 : len(result.scope)-1]
+	}
+}
+
+// formattedMapKeyName is the name of a source map key, with the same name-mapping
+// and case/fuzzy configuration applied as formattedSourceFieldName.
+func (m *Mapper) formattedMapKeyName(key string) string {
+	if m.SourceNameMapper != nil {
+		key = m.SourceNameMapper(key)
+	}
+	return m.fuzzy(key)
+}
+
+// fieldOptions is the parsed form of a single field's `mapper` struct tag, eg.
+// `mapper:"OtherName,omitempty,required,skip,convert=StringToInt"`.
+type fieldOptions struct {
+	rename    string
+	skip      bool
+	required  bool
+	omitempty bool
+	convert   string
+}
+
+// fieldOptionsCacheKey identifies one struct type parsed under one tag name, since
+// Mapper.Tag can vary between Mapper instances sharing the same struct types.
+type fieldOptionsCacheKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// fieldOptionsCache holds the parsed fieldOptions for every field of a struct type,
+// keyed by fieldOptionsCacheKey, so repeated Map calls don't re-parse the same tags.
+var fieldOptionsCache sync.Map // fieldOptionsCacheKey -> map[string]fieldOptions
+
+// tagName returns the struct tag name to parse for per-field options, defaulting to "mapper".
+func (m *Mapper) tagName() string {
+	if m.Tag != "" {
+		return m.Tag
+	}
+	return "mapper"
+}
+
+// fieldOptionsFor returns the parsed mapper tag options for every field of t, keyed
+// by field name. The parse happens once per (type, tag name) pair and is cached.
+func (m *Mapper) fieldOptionsFor(t reflect.Type) map[string]fieldOptions {
+	key := fieldOptionsCacheKey{t: t, tag: m.tagName()}
+	if cached, ok := fieldOptionsCache.Load(key); ok {
+		return cached.(map[string]fieldOptions)
+	}
+	opts := make(map[string]fieldOptions, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if tagVal, ok := f.Tag.Lookup(key.tag); ok {
+			opts[f.Name] = parseFieldOptions(tagVal)
+		}
+	}
+	cached, _ := fieldOptionsCache.LoadOrStore(key, opts)
+	return cached.(map[string]fieldOptions)
+}
+
+// parseFieldOptions parses a comma-separated `mapper` tag value. Any segment,
+// including the first, is recognized as a boolean flag or `convert=Name`; a
+// first segment that isn't one of those keywords is treated as a rename.
+func parseFieldOptions(tagVal string) fieldOptions {
+	var opts fieldOptions
+	parts := strings.Split(tagVal, ",")
+	for i, part := range parts {
+		switch {
+		case part == "skip":
+			opts.skip = true
+		case part == "required":
+			opts.required = true
+		case part == "omitempty":
+			opts.omitempty = true
+		case strings.HasPrefix(part, "convert="):
+			opts.convert = strings.TrimPrefix(part, "convert=")
+		case i == 0 && part != "":
+			opts.rename = part
+		}
+	}
+	return opts
+}
+
+// isZeroValue reports whether v holds its type's zero value.
+func isZeroValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
+// planOp is the precomputed conversion step a fieldPlan will execute.
+type planOp int
+
+const (
+	// opDynamic delegates straight to mapValues: pointers and maps always go through
+	// the general-purpose path, as do slices, interfaces, time.Time and string
+	// coercions whose source and dest types differ (same-typed ones use opDirectSet).
+	opDynamic planOp = iota
+	opDirectSet
+	opIntWiden
+	opUintWiden
+	opFloatWiden
+	opStruct // nested struct -> struct; recurse into a cached subPlan
+	opConverter
+)
+
+// fieldPlan is one destination struct field's precomputed mapping step, resolved
+// once per (sourceType, destType) pair instead of on every Map call.
+type fieldPlan struct {
+	destPath   []int  // index path into the dest struct, through anonymous fields if needed
+	destName   string // for Result.scope / error messages
+	sourcePath []int  // index path into the source struct, through anonymous fields if needed
+	op         planOp
+	convert    string       // set when op == opConverter
+	subPlan    *mappingPlan // set when op == opStruct
+	skip       bool
+	required   bool
+	omitempty  bool
+	missing    bool // no matching source field was found while building the plan
+}
+
+// mappingPlan is the ordered list of fieldPlans for one dest struct type.
+type mappingPlan struct {
+	fields []fieldPlan
+}
+
+// planCacheKey scopes a cached plan to the Mapper config it was built under, since
+// Mapper config (tags, name mappers, IgnoreDestFields, ...) shapes the plan just as
+// much as the two reflect.Types do. It's keyed by Mapper.fingerprint() rather than
+// by *Mapper pointer identity: that lets the common case of repeated zero-value
+// (or identically-configured) Mapper{} literals share one cached plan instead of
+// pinning every ad-hoc *Mapper in memory forever, and it means a Mapper whose
+// config is changed after first use builds a fresh plan instead of reusing a
+// stale one.
+type planCacheKey struct {
+	mapper string
+	source reflect.Type
+	dest   reflect.Type
+}
+
+// planCache holds a *mappingPlan per (Mapper config fingerprint, sourceType, destType),
+// built once on first use and reused by every later Map call for that combination.
+var planCache sync.Map // planCacheKey -> *mappingPlan
+
+// Precompile builds and caches the mapping plan for src and dst's types, so the
+// first real Map call for that pair doesn't pay the cost of walking both structs.
+// Safe to call ahead of time, eg. from an init function or at service startup.
+// Map does not use plans while m has CustomMappers registered, so for such a
+// Mapper this has no effect on later Map calls.
+func (m *Mapper) Precompile(src, dst interface{}) {
+	sourceType := reflect.TypeOf(src)
+	destType := reflect.TypeOf(dst)
+	if sourceType.Kind() == reflect.Ptr {
+		sourceType = sourceType.Elem()
+	}
+	if destType.Kind() == reflect.Ptr {
+		destType = destType.Elem()
+	}
+	if sourceType.Kind() != reflect.Struct || destType.Kind() != reflect.Struct {
+		return
+	}
+	m.planFor(sourceType, destType)
+}
+
+// planFor returns the cached mappingPlan for (sourceType, destType) under this
+// Mapper's config, building and caching it on first use.
+func (m *Mapper) planFor(sourceType, destType reflect.Type) *mappingPlan {
+	key := planCacheKey{mapper: m.fingerprint(), source: sourceType, dest: destType}
+	if cached, ok := planCache.Load(key); ok {
+		return cached.(*mappingPlan)
+	}
+	plan := m.buildPlan(sourceType, destType)
+	cached, _ := planCache.LoadOrStore(key, plan)
+	return cached.(*mappingPlan)
+}
+
+// fingerprint returns a string identifying every part of m's config that buildPlan's
+// output depends on. Keying planCache by this instead of by *Mapper identity makes
+// equally-configured Mappers (including the common repeated Mapper{} literal) share
+// cached plans, and makes a later change to m's config (eg. appending to
+// IgnoreDestFields) produce a different key instead of silently reusing a plan built
+// under the old config.
+func (m *Mapper) fingerprint() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%t|%t|%t|%t|%s|%s|%s|%d|",
+		m.PanicOnMissingField, m.PanicOnIncompatibleTypes, m.IgnoreCase, m.FuzzyMatchFieldNames,
+		m.SourceTag, m.DestTag, m.Tag, m.StringCoercions)
+	b.WriteString(sortedMapFingerprint(m.FieldNameMaps))
+	b.WriteByte('|')
+	b.WriteString(sortedSliceFingerprint(m.IgnoreDestFields))
+	b.WriteByte('|')
+	b.WriteString(sortedSliceFingerprint(m.TimeLayouts))
+	fmt.Fprintf(&b, "|%d|%d|", funcFingerprint(m.SourceNameMapper), funcFingerprint(m.DestNameMapper))
+	for _, fn := range m.CustomMappers {
+		fmt.Fprintf(&b, "%d,", funcFingerprint(fn))
+	}
+	b.WriteByte('|')
+	converterNames := make([]string, 0, len(m.Converters))
+	for name := range m.Converters {
+		converterNames = append(converterNames, name)
+	}
+	sort.Strings(converterNames)
+	for _, name := range converterNames {
+		fmt.Fprintf(&b, "%s=%d,", name, funcFingerprint(m.Converters[name]))
+	}
+	return b.String()
+}
+
+// sortedMapFingerprint renders a map[string]string in a deterministic order, since
+// Go's map iteration order would otherwise make two identically-configured Mappers
+// fingerprint differently.
+func sortedMapFingerprint(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=%s,", k, m[k])
+	}
+	return b.String()
+}
+
+// sortedSliceFingerprint renders a []string independent of the original slice's
+// order, matching m.includes' order-independent membership semantics.
+func sortedSliceFingerprint(s []string) string {
+	sorted := append([]string{}, s...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+// funcFingerprint returns a comparable identity for a func value, or 0 for a nil one.
+// Funcs aren't otherwise comparable, so this is what lets NameMapper/CustomFieldMapper
+// fields participate in fingerprint().
+func funcFingerprint(fn interface{}) uintptr {
+	v := reflect.ValueOf(fn)
+	if !v.IsValid() || v.IsNil() {
+		return 0
+	}
+	return v.Pointer()
+}
+
+// buildPlan walks sourceType and destType once, resolving each dest field's source
+// path and conversion op up front. It mirrors findSourceField/mapField, but works
+// against reflect.Types instead of reflect.Values since none of that resolution
+// depends on the data being mapped.
+func (m *Mapper) buildPlan(sourceType, destType reflect.Type) *mappingPlan {
+	destFields := promotedFieldsFor(destType)
+	plan := &mappingPlan{fields: make([]fieldPlan, 0, len(destFields))}
+
+	for _, destPf := range destFields {
+		destFieldType := destPf.field
+		opts := m.fieldOptionsFor(destPf.owner)[destFieldType.Name]
+		fp := fieldPlan{
+			destPath:  destPf.index,
+			destName:  destFieldType.Name,
+			skip:      opts.skip,
+			required:  opts.required,
+			omitempty: opts.omitempty,
+			convert:   opts.convert,
+		}
+		if fp.skip {
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+
+		destFieldName := m.formattedDestFieldName(destFieldType, opts)
+		if m.includes(m.IgnoreDestFields, destFieldName) || m.destFieldIgnored(destPf) {
+			fp.skip = true
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+
+		path, sourceFieldType, found := m.findSourceFieldPath(sourceType, destFieldName)
+		if !found {
+			fp.missing = true
+			plan.fields = append(plan.fields, fp)
+			continue
+		}
+		fp.sourcePath = path
+
+		switch {
+		case fp.convert != "":
+			fp.op = opConverter
+		case destFieldType.Type.Kind() == reflect.Ptr || destFieldType.Type.Kind() == reflect.Map:
+			// always goes through mapValues: pointers get a fresh pointee (not an alias
+			// of the source pointer) and maps get a fresh map with recursively-copied
+			// entries (not an alias of the source map), even when the types match exactly.
+			fp.op = opDynamic
+		case destFieldType.Type.Kind() == reflect.Struct && sourceFieldType.Kind() == reflect.Struct && !isTimeType(destFieldType.Type):
+			// checked before the type-equality case below so a same-typed nested struct
+			// still recurses through its own fields' mapper tag semantics (skip, required,
+			// omitempty, convert) instead of being copied verbatim via opDirectSet.
+			fp.op = opStruct
+			fp.subPlan = m.planFor(sourceFieldType, destFieldType.Type)
+		case sourceFieldType == destFieldType.Type:
+			fp.op = opDirectSet
+		case isIntTypeName(destFieldType.Type) && (isIntTypeName(sourceFieldType) || isUintTypeName(sourceFieldType)):
+			fp.op = opIntWiden
+		case isUintTypeName(destFieldType.Type) && isUintTypeName(sourceFieldType):
+			fp.op = opUintWiden
+		case isFloatTypeName(destFieldType.Type) && isFloatTypeName(sourceFieldType):
+			fp.op = opFloatWiden
+		default:
+			fp.op = opDynamic
+		}
+		plan.fields = append(plan.fields, fp)
+	}
+	return plan
+}
+
+// findSourceFieldPath is the type-only equivalent of findSourceField: it searches
+// sourceType's promoted field set and returns the index path (through anonymous
+// composed structs if necessary) to the field matching destFieldName, and that
+// field's type.
+func (m *Mapper) findSourceFieldPath(sourceType reflect.Type, destFieldName string) ([]int, reflect.Type, bool) {
+	for _, pf := range promotedFieldsFor(sourceType) {
+		opts := m.fieldOptionsFor(pf.owner)[pf.field.Name]
+		if opts.skip {
+			continue
+		}
+		if m.formattedSourceFieldName(pf.field, opts) == destFieldName {
+			return pf.index, pf.field.Type, true
+		}
+	}
+	return nil, nil, false
+}
+
+// isIntTypeName, isUintTypeName and isFloatTypeName are the reflect.Type counterparts
+// of isIntType/isUintType/isFloatType, used while building a plan where only types
+// (not yet any values) are available.
+func isIntTypeName(t reflect.Type) bool {
+	switch t.Name() {
+	case "int", "int8", "int16", "int32", "int64":
+		return true
+	}
+	return false
+}
+
+func isUintTypeName(t reflect.Type) bool {
+	switch t.Name() {
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func isFloatTypeName(t reflect.Type) bool {
+	switch t.Name() {
+	case "float32", "float64":
+		return true
+	}
+	return false
+}
+
+// execPlan runs a precomputed mappingPlan's field ops against sourceVal/destVal,
+// reproducing the same Result semantics (errors, MissingSourceFields, scope path)
+// as the dynamic mapField/findSourceField path.
+func (m *Mapper) execPlan(result *Result, plan *mappingPlan, sourceVal, destVal reflect.Value) {
+	for _, fp := range plan.fields {
+		result.scope = append(result.scope, fp.destName)
+		m.execFieldPlan(result, fp, sourceVal, destVal)
+		result.scope = result.scope[0 : len(result.scope)-1]
+	}
+}
+
+// execFieldPlan executes a single fieldPlan.
+func (m *Mapper) execFieldPlan(result *Result, fp fieldPlan, sourceVal, destVal reflect.Value) {
+	if fp.skip {
+		return
+	}
+	if fp.missing {
+		result.MissingSourceFields = append(result.MissingSourceFields, result.scopedFieldName())
+		return
+	}
+
+	sourceField, ok := fieldByIndexPath(sourceVal, fp.sourcePath)
+	if !ok {
+		// an anonymous pointer embed along sourcePath was nil at runtime, even though
+		// the field existed structurally when the plan was built.
+		result.MissingSourceFields = append(result.MissingSourceFields, result.scopedFieldName())
+		return
+	}
+	destField := fieldByIndexPathForSet(destVal, fp.destPath)
+
+	// catch any type-conversion panic so we can add some context to it, same as mapField.
+	defer func() {
+		if r := recover(); r != nil {
+			errMsg := fmt.Sprintf("Error mapping field: %s. DestType: %v. SourceType: %v. Error: %v", fp.destName, destVal.Type(), sourceVal.Type(), r)
+			if m.PanicOnIncompatibleTypes {
+				panic(errMsg)
+			}
+			result.addError(errMsg)
+		}
+	}()
+
+	if fp.required && isZeroValue(sourceField) {
+		result.addError(fmt.Sprintf("Required field has a zero value: %s", result.scopedFieldName()))
+		return
+	}
+	if fp.omitempty && isZeroValue(sourceField) && !isZeroValue(destField) {
+		return
+	}
+
+	switch fp.op {
+	case opConverter:
+		if converter, ok := m.Converters[fp.convert]; ok {
+			if handled := converter(sourceField, sourceField.Type(), destField, destField.Type()); handled {
+				return
+			}
+		}
+		m.mapValues(result, sourceField, destField)
+	case opDirectSet:
+		destField.Set(sourceField)
+	case opIntWiden:
+		if ok, uval := isUintType(sourceField); ok {
+			destField.SetInt(int64(uval))
+			return
+		}
+		_, ival := isIntType(sourceField)
+		destField.SetInt(ival)
+	case opUintWiden:
+		_, uval := isUintType(sourceField)
+		destField.SetUint(uval)
+	case opFloatWiden:
+		_, fval := isFloatType(sourceField)
+		destField.SetFloat(fval)
+	case opStruct:
+		m.execPlan(result, fp.subPlan, sourceField, destField)
+	default:
+		m.mapValues(result, sourceField, destField)
+	}
+}
+
 // mapSlice maps a slice from source to dest, creating elements as necessary.
 func (m *Mapper) mapSlice(result *Result, sourceVal, destVal reflect.Value) {
 	destType := destVal.Type()
@@ -387,3 +1266,140 @@ func isFloatType(x reflect.Value) (bool, float64) {
 	}
 	return false, 0
 }
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// isTimeType returns true if t is time.Time.
+func isTimeType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == "time" && t.Name() == "Time"
+}
+
+// isNumericKind returns true for the kinds strconv can parse a string into or format
+// a string from: bool and the int/uint/float families.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// timeLayouts returns the layouts to try when parsing a string into a time.Time,
+// defaulting to time.RFC3339. The first layout is also used for formatting.
+func (m *Mapper) timeLayouts() []string {
+	if len(m.TimeLayouts) > 0 {
+		return m.TimeLayouts
+	}
+	return []string{time.RFC3339}
+}
+
+// coerceString attempts the built-in string<->time.Time, string<->time.Duration and
+// string<->bool/int/float "wire format" conversions gated by Mapper.StringCoercions.
+// Returns true if it fully handled the source/dest pair, whether that ended in a
+// successful conversion or a recorded/panicked error.
+func (m *Mapper) coerceString(result *Result, sourceVal, destVal reflect.Value) bool {
+	sourceType := sourceVal.Type()
+	destType := destVal.Type()
+
+	switch {
+	case sourceType.Kind() == reflect.String && isTimeType(destType):
+		if m.StringCoercions&StringToTime == 0 {
+			return false
+		}
+		for _, layout := range m.timeLayouts() {
+			if parsed, err := time.Parse(layout, sourceVal.String()); err == nil {
+				destVal.Set(reflect.ValueOf(parsed))
+				return true
+			}
+		}
+		return m.coercionError(result, sourceVal, destVal, fmt.Errorf("no TimeLayouts matched %q", sourceVal.String()))
+
+	case isTimeType(sourceType) && destType.Kind() == reflect.String:
+		if m.StringCoercions&TimeToString == 0 {
+			return false
+		}
+		destVal.SetString(sourceVal.Interface().(time.Time).Format(m.timeLayouts()[0]))
+		return true
+
+	case sourceType.Kind() == reflect.String && destType == durationType:
+		if m.StringCoercions&StringToDuration == 0 {
+			return false
+		}
+		d, err := time.ParseDuration(sourceVal.String())
+		if err != nil {
+			return m.coercionError(result, sourceVal, destVal, err)
+		}
+		destVal.SetInt(int64(d))
+		return true
+
+	case sourceType == durationType && destType.Kind() == reflect.String:
+		if m.StringCoercions&DurationToString == 0 {
+			return false
+		}
+		destVal.SetString(sourceVal.Interface().(time.Duration).String())
+		return true
+
+	case sourceType.Kind() == reflect.String && isNumericKind(destType.Kind()):
+		if m.StringCoercions&StringToNumber == 0 {
+			return false
+		}
+		return m.coerceStringToNumber(result, sourceVal, destVal)
+
+	case isNumericKind(sourceType.Kind()) && destType.Kind() == reflect.String:
+		if m.StringCoercions&NumberToString == 0 {
+			return false
+		}
+		destVal.SetString(fmt.Sprint(sourceVal.Interface()))
+		return true
+	}
+	return false
+}
+
+// coerceStringToNumber parses a string into a bool/int/uint/float dest, per strconv.
+func (m *Mapper) coerceStringToNumber(result *Result, sourceVal, destVal reflect.Value) bool {
+	s := sourceVal.String()
+	switch destVal.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return m.coercionError(result, sourceVal, destVal, err)
+		}
+		destVal.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return m.coercionError(result, sourceVal, destVal, err)
+		}
+		destVal.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return m.coercionError(result, sourceVal, destVal, err)
+		}
+		destVal.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return m.coercionError(result, sourceVal, destVal, err)
+		}
+		destVal.SetFloat(n)
+	default:
+		return false
+	}
+	return true
+}
+
+// coercionError records (or panics with, per PanicOnIncompatibleTypes) a failed
+// string coercion. Always returns true: the pair was recognized and handled, even
+// though the conversion itself failed.
+func (m *Mapper) coercionError(result *Result, sourceVal, destVal reflect.Value, cause error) bool {
+	errMsg := fmt.Sprintf("Could not coerce string %q to %s: %v", sourceVal.String(), destVal.Type(), cause)
+	if m.PanicOnIncompatibleTypes {
+		panic(errMsg)
+	}
+	result.addError(errMsg)
+	return true
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -415,6 +417,617 @@ func TestDestComposedStruct(t *testing.T) {
 	assert.Empty(t, r.Errors)
 }
 
+func TestSourceNameMapperSnakeCase(t *testing.T) {
+	src := struct {
+		UserID string
+	}{UserID: "abc"}
+	dest := struct {
+		UserID string
+	}{}
+
+	m := Mapper{SourceNameMapper: SnakeCase, DestNameMapper: SnakeCase}
+	r := m.Map(&src, &dest)
+	assert.Equal(t, "abc", dest.UserID)
+	assert.Empty(t, r.Errors)
+}
+
+func TestAsymmetricNamingConventionsSnakeSourceToPascalDest(t *testing.T) {
+	type source struct {
+		User_Id    string
+		First_Name string
+	}
+	type dest struct {
+		UserId    string
+		FirstName string
+	}
+	src := source{User_Id: "42", First_Name: "Ada"}
+	d := dest{}
+
+	m := Mapper{SourceNameMapper: SnakeCase, DestNameMapper: SnakeCase}
+	r := m.Map(&src, &d)
+	assert.Equal(t, "42", d.UserId)
+	assert.Equal(t, "Ada", d.FirstName)
+	assert.Empty(t, r.Errors)
+}
+
+func TestNameMapperWithSourceAndDestTags(t *testing.T) {
+	source := struct {
+		UserID string `json:"user_id"`
+	}{UserID: "7"}
+	dest := struct {
+		UserID string `json:"USER_ID"`
+	}{}
+
+	m := Mapper{
+		SourceTag:        "json",
+		DestTag:          "json",
+		SourceNameMapper: SnakeCase,
+		DestNameMapper:   SnakeCase,
+	}
+	r := m.Map(&source, &dest)
+	assert.Equal(t, "7", dest.UserID)
+	assert.Empty(t, r.Errors)
+}
+
+func TestBuiltinNameMappers(t *testing.T) {
+	assert.Equal(t, "user_id", SnakeCase("UserID"))
+	assert.Equal(t, "user-id", KebabCase("UserID"))
+	assert.Equal(t, "USER_ID", AllCapsUnderscore("UserID"))
+	assert.Equal(t, "UserId", PascalCase("user_id"))
+	assert.Equal(t, "userId", LowerCamelCase("user_id"))
+}
+
+func TestTagOptionSkipOnDestField(t *testing.T) {
+	source := struct {
+		Foo string
+	}{Foo: "bar"}
+	dest := struct {
+		Foo string `mapper:",skip"`
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Empty(t, dest.Foo)
+	assert.Empty(t, r.Errors)
+}
+
+func TestTagOptionSkipWithoutLeadingComma(t *testing.T) {
+	source := struct {
+		Foo string
+	}{Foo: "bar"}
+	dest := struct {
+		Foo string `mapper:"skip"`
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Empty(t, dest.Foo)
+	assert.Empty(t, r.Errors)
+}
+
+func TestTagOptionRename(t *testing.T) {
+	source := struct {
+		Foo string `mapper:"Bar"`
+	}{Foo: "baz"}
+	dest := struct {
+		Bar string
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, "baz", dest.Bar)
+	assert.Empty(t, r.Errors)
+}
+
+func TestTagOptionRequiredAddsError(t *testing.T) {
+	source := struct {
+		Foo string
+	}{}
+	dest := struct {
+		Foo string `mapper:",required"`
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+}
+
+func TestTagOptionOmitemptyKeepsExistingDestValue(t *testing.T) {
+	source := struct {
+		Foo string
+	}{}
+	dest := struct {
+		Foo string `mapper:",omitempty"`
+	}{Foo: "existing"}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, "existing", dest.Foo)
+	assert.Empty(t, r.Errors)
+}
+
+func TestTagOptionConvertUsesNamedConverter(t *testing.T) {
+	source := struct {
+		Foo string
+	}{Foo: "42"}
+	dest := struct {
+		Foo int `mapper:",convert=StringToInt"`
+	}{}
+
+	m := Mapper{
+		Converters: map[string]CustomFieldMapper{
+			"StringToInt": func(sourceVal reflect.Value, sourceType reflect.Type, destVal reflect.Value, destType reflect.Type) bool {
+				n, err := strconv.Atoi(sourceVal.String())
+				if err != nil {
+					return false
+				}
+				destVal.SetInt(int64(n))
+				return true
+			},
+		},
+	}
+	r := m.Map(&source, &dest)
+	assert.Equal(t, 42, dest.Foo)
+	assert.Empty(t, r.Errors)
+}
+
+func TestCustomTagNameViaMapperTag(t *testing.T) {
+	source := struct {
+		Foo string `dto:"Bar"`
+	}{Foo: "baz"}
+	dest := struct {
+		Bar string
+	}{}
+
+	m := Mapper{Tag: "dto"}
+	r := m.Map(&source, &dest)
+	assert.Equal(t, "baz", dest.Bar)
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapToMap(t *testing.T) {
+	source := struct {
+		Values map[string]int
+	}{Values: map[string]int{"a": 1, "b": 2}}
+	dest := struct {
+		Values map[string]int
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, dest.Values)
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapToMapWithNilSourceYieldsNilDest(t *testing.T) {
+	source := struct {
+		Values map[string]int
+	}{}
+	dest := struct {
+		Values map[string]int
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Nil(t, dest.Values)
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapOfStructsToMapOfStructs(t *testing.T) {
+	source := struct {
+		Values map[string]SourceTypeA
+	}{Values: map[string]SourceTypeA{"a": {Foo: 1, Bar: "one"}}}
+	dest := struct {
+		Values map[string]DestTypeA
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, 1, dest.Values["a"].Foo)
+	assert.Equal(t, "one", dest.Values["a"].Bar)
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapOfPointersToMapOfPointers(t *testing.T) {
+	source := struct {
+		Values map[string]*SourceTypeA
+	}{Values: map[string]*SourceTypeA{"a": {Foo: 1, Bar: "one"}, "b": nil}}
+	dest := struct {
+		Values map[string]*DestTypeA
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, 1, dest.Values["a"].Foo)
+	assert.Equal(t, "one", dest.Values["a"].Bar)
+	assert.Nil(t, dest.Values["b"])
+	assert.Empty(t, r.Errors)
+}
+
+func TestStructFlattenedIntoMap(t *testing.T) {
+	source := SourceTypeA{Foo: 42, Bar: "hi"}
+	dest := map[string]interface{}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, 42, dest["Foo"])
+	assert.Equal(t, "hi", dest["Bar"])
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapToStruct(t *testing.T) {
+	source := map[string]interface{}{"Foo": 42, "Bar": "hi"}
+	dest := DestTypeA{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Equal(t, 42, dest.Foo)
+	assert.Equal(t, "hi", dest.Bar)
+	assert.Empty(t, r.Errors)
+}
+
+func TestMapToStructWithMissingKeyAddsError(t *testing.T) {
+	source := map[string]interface{}{"Foo": 42}
+	dest := DestTypeA{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+}
+
+func TestStringToTimeCoercion(t *testing.T) {
+	source := struct {
+		CreatedAt string
+	}{CreatedAt: "2020-01-02T15:04:05Z"}
+	dest := struct {
+		CreatedAt time.Time
+	}{}
+
+	m := Mapper{StringCoercions: StringToTime}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, 2020, dest.CreatedAt.Year())
+}
+
+func TestTimeToStringCoercion(t *testing.T) {
+	source := struct {
+		CreatedAt time.Time
+	}{CreatedAt: time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)}
+	dest := struct {
+		CreatedAt string
+	}{}
+
+	m := Mapper{StringCoercions: TimeToString}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, "2020-01-02T15:04:05Z", dest.CreatedAt)
+}
+
+func TestStringToTimeCoercionWithCustomLayout(t *testing.T) {
+	source := struct {
+		CreatedAt string
+	}{CreatedAt: "02/01/2020"}
+	dest := struct {
+		CreatedAt time.Time
+	}{}
+
+	m := Mapper{StringCoercions: StringToTime, TimeLayouts: []string{"02/01/2006"}}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, 2020, dest.CreatedAt.Year())
+}
+
+func TestStringToDurationCoercion(t *testing.T) {
+	source := struct {
+		Timeout string
+	}{Timeout: "5s"}
+	dest := struct {
+		Timeout time.Duration
+	}{}
+
+	m := Mapper{StringCoercions: StringToDuration}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, 5*time.Second, dest.Timeout)
+}
+
+func TestDurationToStringCoercion(t *testing.T) {
+	source := struct {
+		Timeout time.Duration
+	}{Timeout: 5 * time.Second}
+	dest := struct {
+		Timeout string
+	}{}
+
+	m := Mapper{StringCoercions: DurationToString}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, "5s", dest.Timeout)
+}
+
+func TestStringToNumberCoercion(t *testing.T) {
+	source := struct {
+		Count string
+		Ratio string
+		Ok    string
+	}{Count: "42", Ratio: "3.14", Ok: "true"}
+	dest := struct {
+		Count int
+		Ratio float64
+		Ok    bool
+	}{}
+
+	m := Mapper{StringCoercions: StringToNumber}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, 42, dest.Count)
+	assert.InDelta(t, 3.14, dest.Ratio, 0.0001)
+	assert.True(t, dest.Ok)
+}
+
+func TestNumberToStringCoercion(t *testing.T) {
+	source := struct {
+		Count int
+	}{Count: 42}
+	dest := struct {
+		Count string
+	}{}
+
+	m := Mapper{StringCoercions: NumberToString}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, "42", dest.Count)
+}
+
+func TestStringCoercionsDisabledByDefault(t *testing.T) {
+	source := struct {
+		Count string
+	}{Count: "42"}
+	dest := struct {
+		Count int
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+}
+
+func TestStringCoercionsCanBeIndividuallyDisabled(t *testing.T) {
+	source := struct {
+		Count string
+	}{Count: "42"}
+	dest := struct {
+		Count int
+	}{}
+
+	m := Mapper{StringCoercions: AllStringCoercions &^ StringToNumber}
+	r := m.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+}
+
+func TestCompiledPlanProducesSameResultAsDynamicMapping(t *testing.T) {
+	source := SourceParent{Children: []SourceTypeA{{Foo: 1, Bar: "a"}, {Foo: 2, Bar: "b"}}}
+	dest := DestParent{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, []DestTypeA{{Foo: 1, Bar: "a"}, {Foo: 2, Bar: "b"}}, dest.Children)
+}
+
+func TestCompiledPlanIsReusedAcrossMapCalls(t *testing.T) {
+	m := Mapper{}
+	source1 := SourceTypeA{Foo: 1, Bar: "a"}
+	dest1 := DestTypeA{}
+	m.Map(&source1, &dest1)
+
+	plan := m.planFor(reflect.TypeOf(source1), reflect.TypeOf(dest1))
+
+	source2 := SourceTypeA{Foo: 2, Bar: "b"}
+	dest2 := DestTypeA{}
+	m.Map(&source2, &dest2)
+
+	assert.Same(t, plan, m.planFor(reflect.TypeOf(source1), reflect.TypeOf(dest1)))
+	assert.Equal(t, DestTypeA{Foo: 2, Bar: "b"}, dest2)
+}
+
+func TestCompiledPlanIsNotSharedAcrossDifferentlyConfiguredMappers(t *testing.T) {
+	type source struct {
+		UserID int
+	}
+	type dest struct {
+		ID int
+	}
+
+	plain := Mapper{PanicOnIncompatibleTypes: false}
+	r1 := plain.Map(&source{UserID: 1}, &dest{})
+	assert.NotEmpty(t, r1.Errors)
+
+	renaming := Mapper{FieldNameMaps: map[string]string{"UserID": "ID"}}
+	d := dest{}
+	r2 := renaming.Map(&source{UserID: 1}, &d)
+	assert.Empty(t, r2.Errors)
+	assert.Equal(t, 1, d.ID)
+}
+
+func TestCompiledPlanHonorsTagsOnSameTypedNestedStruct(t *testing.T) {
+	type meta struct {
+		Secret string `mapper:",skip"`
+		Public string
+	}
+	type source struct {
+		Meta meta
+	}
+	type dest struct {
+		Meta meta
+	}
+
+	m := Mapper{}
+	s := source{Meta: meta{Secret: "hidden", Public: "shown"}}
+	d := dest{}
+	r := m.Map(&s, &d)
+
+	assert.Empty(t, r.Errors)
+	assert.Empty(t, d.Meta.Secret)
+	assert.Equal(t, "shown", d.Meta.Public)
+}
+
+func TestCompiledPlanPicksUpConfigChangedAfterFirstUse(t *testing.T) {
+	type source struct {
+		ID   int
+		Name string
+	}
+	type dest struct {
+		ID   int
+		Name string
+	}
+
+	m := Mapper{}
+	d1 := dest{}
+	r1 := m.Map(&source{ID: 1, Name: "a"}, &d1)
+	assert.Empty(t, r1.Errors)
+	assert.Equal(t, "a", d1.Name)
+
+	m.IgnoreDestFields = append(m.IgnoreDestFields, "Name")
+	d2 := dest{}
+	r2 := m.Map(&source{ID: 2, Name: "b"}, &d2)
+	assert.Empty(t, r2.Errors)
+	assert.Equal(t, 2, d2.ID)
+	assert.Empty(t, d2.Name)
+}
+
+func TestPrecompileWarmsThePlanCache(t *testing.T) {
+	m := Mapper{}
+	m.Precompile(SourceTypeA{}, &DestTypeA{})
+
+	plan, ok := planCache.Load(planCacheKey{mapper: m.fingerprint(), source: reflect.TypeOf(SourceTypeA{}), dest: reflect.TypeOf(DestTypeA{})})
+	assert.True(t, ok)
+	assert.NotNil(t, plan)
+}
+
+func TestCompiledPlanAllocatesFreshPointeeForPointerFields(t *testing.T) {
+	source := struct {
+		Name  string
+		Child *SourceTypeA
+	}{Name: "a", Child: &SourceTypeA{Foo: 1, Bar: "b"}}
+	dest := struct {
+		Name  string
+		Child *DestTypeA
+	}{}
+
+	m := Mapper{}
+	r := m.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, &DestTypeA{Foo: 1, Bar: "b"}, dest.Child)
+
+	// mutating the source's pointee afterwards must not affect dest: the plan's
+	// opDynamic path for pointer fields allocates a fresh pointee rather than aliasing.
+	source.Child.Foo = 99
+	assert.Equal(t, 1, dest.Child.Foo)
+}
+
+func BenchmarkMapNestedSliceOfStructs(b *testing.B) {
+	source := SourceParent{Children: make([]SourceTypeA, 100)}
+	for i := range source.Children {
+		source.Children[i] = SourceTypeA{Foo: i, Bar: "value"}
+	}
+	m := Mapper{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dest := DestParent{}
+		m.Map(&source, &dest)
+	}
+}
+
+func TestPromotedFieldOnSourceOnly(t *testing.T) {
+	type address struct {
+		City string
+	}
+	source := struct {
+		address
+		Name string
+	}{address: address{City: "Lagos"}, Name: "Ada"}
+	dest := struct {
+		Name string
+		City string
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, "Ada", dest.Name)
+	assert.Equal(t, "Lagos", dest.City)
+}
+
+func TestPromotedFieldOnDestOnly(t *testing.T) {
+	type address struct {
+		City string
+	}
+	source := struct {
+		Name string
+		City string
+	}{Name: "Ada", City: "Lagos"}
+	dest := struct {
+		address
+		Name string
+	}{}
+
+	// the source is intentionally flat, so the embedded "address" field itself can
+	// never match a same-named source field; only its promoted City is expected to
+	// resolve. IgnoreDestFields isn't used here since it would also suppress City.
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+	assert.Equal(t, "Ada", dest.Name)
+	assert.Equal(t, "Lagos", dest.City)
+}
+
+func TestPromotedFieldOnBothSides(t *testing.T) {
+	type sourceAddress struct {
+		City string
+	}
+	type destAddress struct {
+		City string
+	}
+	source := struct {
+		sourceAddress
+		Name string
+	}{sourceAddress: sourceAddress{City: "Lagos"}, Name: "Ada"}
+	dest := struct {
+		destAddress
+		Name string
+	}{}
+
+	// "destAddress" has no same-named source field either, for the same reason as
+	// above; only the promoted City is expected to resolve.
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+	assert.Equal(t, "Ada", dest.Name)
+	assert.Equal(t, "Lagos", dest.City)
+}
+
+func TestOuterFieldShadowsPromotedFieldOfSameName(t *testing.T) {
+	type inner struct {
+		City string
+	}
+	source := struct {
+		inner
+		City string
+	}{inner: inner{City: "Promoted"}, City: "Outer"}
+	dest := struct {
+		City string
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.Empty(t, r.Errors)
+	assert.Equal(t, "Outer", dest.City)
+}
+
+func TestDiamondDoubleEmbedIsAmbiguousAndDropped(t *testing.T) {
+	type left struct {
+		City string
+	}
+	type right struct {
+		City string
+	}
+	source := struct {
+		left
+		right
+	}{left: left{City: "FromLeft"}, right: right{City: "FromRight"}}
+	dest := struct {
+		City string
+	}{}
+
+	r := testMapper.Map(&source, &dest)
+	assert.NotEmpty(t, r.Errors)
+	assert.Equal(t, "", dest.City)
+}
+
 type SourceParent struct {
 	Children []SourceTypeA
 }